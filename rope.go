@@ -5,6 +5,7 @@ package rope
 import (
 	"bytes"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
@@ -19,9 +20,9 @@ const (
 //
 // This persistence makes it easy to store old versions of a Rope just by holding on to old roots.
 type Rope struct {
-	content       string
-	length, depth int
-	left, right   *Rope
+	content                        string
+	length, depth, runes, newlines int
+	left, right                    *Rope
 }
 
 // Return a new empty rope.
@@ -30,8 +31,10 @@ func New() Rope {
 }
 
 // Return a new rope with the contents of string s.
+// The rope's runes and newlines are counted at construction time so that
+// later rune- and line-oriented lookups can be answered in O(log n) time.
 func NewString(s string) Rope {
-	return Rope{content: s, length: len(s)}
+	return Rope{content: s, length: len(s), runes: utf8.RuneCountInString(s), newlines: strings.Count(s, "\n")}
 }
 
 // Notice that all of the methods take and return ropes by value.
@@ -53,10 +56,12 @@ func (rope Rope) Append(other Rope) Rope {
 			depth = other.depth
 		}
 		return Rope{
-			length: rope.length + other.length,
-			depth:  depth + 1,
-			left:   &rope,
-			right:  &other,
+			length:   rope.length + other.length,
+			depth:    depth + 1,
+			runes:    rope.runes + other.runes,
+			newlines: rope.newlines + other.newlines,
+			left:     &rope,
+			right:    &other,
 		}.rebalanceIfNeeded()
 	}
 }
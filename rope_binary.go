@@ -0,0 +1,189 @@
+package rope
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	binaryMagic   = "RoPe"
+	binaryVersion = 1
+
+	recordLeaf = 0x4C // 'L'
+	recordNode = 0x4E // 'N'
+)
+
+// WriteTree writes rope to w in a compact framed binary format: a header
+// giving the magic, version and total length, followed by a preorder walk
+// of the tree emitting a leaf record (length + bytes) for each leaf and a
+// node record (left length + right length) for each internal node.
+func (rope Rope) WriteTree(w io.Writer) error {
+	header := make([]byte, 0, len(binaryMagic)+1+8)
+	header = append(header, binaryMagic...)
+	header = append(header, binaryVersion)
+	header = binary.BigEndian.AppendUint64(header, uint64(rope.length))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	return writeTreeNode(w, rope)
+}
+
+func writeTreeNode(w io.Writer, rope Rope) error {
+	if rope.isLeaf() {
+		if _, err := w.Write([]byte{recordLeaf}); err != nil {
+			return err
+		}
+
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(rope.content)))
+		if _, err := w.Write(length); err != nil {
+			return err
+		}
+
+		_, err := io.WriteString(w, rope.content)
+		return err
+	}
+
+	if _, err := w.Write([]byte{recordNode}); err != nil {
+		return err
+	}
+
+	sizes := make([]byte, 16)
+	binary.BigEndian.PutUint64(sizes[0:8], uint64(rope.left.length))
+	binary.BigEndian.PutUint64(sizes[8:16], uint64(rope.right.length))
+	if _, err := w.Write(sizes); err != nil {
+		return err
+	}
+
+	if err := writeTreeNode(w, *rope.left); err != nil {
+		return err
+	}
+	return writeTreeNode(w, *rope.right)
+}
+
+// ReadTree reads a rope previously written by WriteTree.
+// Rather than rebuilding the tree by repeated Append calls, it collects the
+// leaves in order and feeds them through merge once, producing a balanced
+// tree in a single pass.
+func ReadTree(r io.Reader) (Rope, error) {
+	header := make([]byte, len(binaryMagic)+1+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return Rope{}, err
+	}
+
+	if string(header[0:len(binaryMagic)]) != binaryMagic {
+		return Rope{}, fmt.Errorf("rope: bad magic in binary tree")
+	}
+
+	version := header[len(binaryMagic)]
+	if version != binaryVersion {
+		return Rope{}, fmt.Errorf("rope: unsupported binary tree version %d", version)
+	}
+	total := binary.BigEndian.Uint64(header[len(binaryMagic)+1:])
+
+	var leaves []Rope
+	if err := readTreeNode(r, &leaves); err != nil {
+		return Rope{}, err
+	}
+
+	if len(leaves) == 0 {
+		return Rope{}, nil
+	}
+
+	result := merge(leaves, 0, len(leaves))
+	if uint64(result.length) != total {
+		return Rope{}, fmt.Errorf("rope: corrupt binary tree: expected %d bytes, got %d", total, result.length)
+	}
+
+	return result, nil
+}
+
+func readTreeNode(r io.Reader, leaves *[]Rope) error {
+	tag := make([]byte, 1)
+	if _, err := io.ReadFull(r, tag); err != nil {
+		return err
+	}
+
+	switch tag[0] {
+	case recordLeaf:
+		length := make([]byte, 4)
+		if _, err := io.ReadFull(r, length); err != nil {
+			return err
+		}
+
+		content := make([]byte, binary.BigEndian.Uint32(length))
+		if _, err := io.ReadFull(r, content); err != nil {
+			return err
+		}
+
+		*leaves = append(*leaves, NewString(string(content)))
+		return nil
+
+	case recordNode:
+		// The left/right lengths are informational only: the actual
+		// structure comes from recursively parsing the two subtrees.
+		sizes := make([]byte, 16)
+		if _, err := io.ReadFull(r, sizes); err != nil {
+			return err
+		}
+
+		if err := readTreeNode(r, leaves); err != nil {
+			return err
+		}
+		return readTreeNode(r, leaves)
+
+	default:
+		return fmt.Errorf("rope: unknown binary tree record type %#x", tag[0])
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (rope Rope) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := rope.WriteTree(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (rope *Rope) UnmarshalBinary(data []byte) error {
+	result, err := ReadTree(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	*rope = result
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (rope Rope) GobEncode() ([]byte, error) {
+	return rope.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (rope *Rope) GobDecode(data []byte) error {
+	return rope.UnmarshalBinary(data)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the rope as a base64 string of its binary form.
+func (rope Rope) MarshalJSON() ([]byte, error) {
+	data, err := rope.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (rope *Rope) UnmarshalJSON(data []byte) error {
+	var raw []byte
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return rope.UnmarshalBinary(raw)
+}
@@ -0,0 +1,124 @@
+package rope
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMarshalUnmarshalBinary(t *testing.T) {
+	rope := NewString(strings.Repeat("hello, world ", 512))
+
+	data, err := rope.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded Rope
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectString(rope.String(), decoded.String(), t)
+}
+
+func TestWriteReadTree(t *testing.T) {
+	rope := NewString("how ").AppendString("now ").AppendString("brown cow")
+
+	var buf bytes.Buffer
+	if err := rope.WriteTree(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, err := ReadTree(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectString(rope.String(), decoded.String(), t)
+}
+
+func TestMarshalUnmarshalBinaryMultiLeaf(t *testing.T) {
+	rope := NewString(strings.Repeat("a", maxLeafSize+1)).Append(NewString(strings.Repeat("b", maxLeafSize+1)))
+
+	data, err := rope.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded Rope
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectString(rope.String(), decoded.String(), t)
+}
+
+func TestWriteReadTreeMultiLeaf(t *testing.T) {
+	rope := NewString(strings.Repeat("a", maxLeafSize+1)).Append(NewString(strings.Repeat("b", maxLeafSize+1)))
+	if rope.isLeaf() {
+		t.Fatalf("expected a multi-leaf tree")
+	}
+
+	var buf bytes.Buffer
+	if err := rope.WriteTree(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, err := ReadTree(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectString(rope.String(), decoded.String(), t)
+}
+
+func TestMarshalBinaryEmpty(t *testing.T) {
+	rope := New()
+
+	data, err := rope.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded Rope
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectString("", decoded.String(), t)
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	rope := NewString("hello, world")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(rope); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded Rope
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectString(rope.String(), decoded.String(), t)
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	rope := NewString("hello, world")
+
+	data, err := json.Marshal(rope)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded Rope
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectString(rope.String(), decoded.String(), t)
+}
@@ -0,0 +1,86 @@
+package rope
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// A Buffer is a concurrency-safe holder for a single "current" Rope, for
+// coordinating multiple writers that all want to update the same document.
+//
+// Load, Store and Transform may be called from any number of goroutines
+// without external locking. Transform is a compare-and-swap loop: if two
+// goroutines race, one wins and the other's mutator is simply re-run
+// against the winner's result, so the mutator passed to Transform must be
+// a pure function of its input with no side effects other than its return
+// value.
+type Buffer struct {
+	root        atomic.Pointer[Rope]
+	mu          sync.Mutex
+	subscribers []chan Rope
+}
+
+// Return a new Buffer holding the given rope.
+func NewBuffer(rope Rope) *Buffer {
+	buffer := &Buffer{}
+	buffer.root.Store(&rope)
+	return buffer
+}
+
+// Return the buffer's current rope.
+func (buffer *Buffer) Load() Rope {
+	return *buffer.root.Load()
+}
+
+// Snapshot is an alias for Load, for readers who want a consistent,
+// unchanging view of the buffer's contents at a point in time.
+func (buffer *Buffer) Snapshot() Rope {
+	return buffer.Load()
+}
+
+// Replace the buffer's current rope, notifying any subscribers.
+func (buffer *Buffer) Store(rope Rope) {
+	buffer.root.Store(&rope)
+	buffer.notify(rope)
+}
+
+// Transform atomically replaces the buffer's rope with mutate's result.
+// If another goroutine updates the buffer first, mutate is re-run against
+// the new value and the attempt is retried until it succeeds. Returns the
+// rope that was ultimately stored.
+func (buffer *Buffer) Transform(mutate func(Rope) Rope) Rope {
+	for {
+		old := buffer.root.Load()
+		next := mutate(*old)
+		if buffer.root.CompareAndSwap(old, &next) {
+			buffer.notify(next)
+			return next
+		}
+	}
+}
+
+// Subscribe returns a channel that receives the buffer's rope every time it changes.
+// Delivery is best-effort: a subscriber that isn't keeping up may miss
+// intermediate versions, but can always call Load to catch up to the
+// current one.
+func (buffer *Buffer) Subscribe() <-chan Rope {
+	ch := make(chan Rope, 1)
+
+	buffer.mu.Lock()
+	buffer.subscribers = append(buffer.subscribers, ch)
+	buffer.mu.Unlock()
+
+	return ch
+}
+
+func (buffer *Buffer) notify(rope Rope) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	for _, ch := range buffer.subscribers {
+		select {
+		case ch <- rope:
+		default:
+		}
+	}
+}
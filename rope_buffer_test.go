@@ -0,0 +1,58 @@
+package rope
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestBufferLoadStore(t *testing.T) {
+	buffer := NewBuffer(NewString("hello"))
+	expectString("hello", buffer.Load().String(), t)
+
+	buffer.Store(NewString("world"))
+	expectString("world", buffer.Load().String(), t)
+	expectString("world", buffer.Snapshot().String(), t)
+}
+
+func TestBufferTransform(t *testing.T) {
+	buffer := NewBuffer(NewString("hello"))
+
+	result := buffer.Transform(func(rope Rope) Rope {
+		return rope.AppendString(", world")
+	})
+
+	expectString("hello, world", result.String(), t)
+	expectString("hello, world", buffer.Load().String(), t)
+}
+
+func TestBufferTransformConcurrent(t *testing.T) {
+	buffer := NewBuffer(NewString(""))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buffer.Transform(func(rope Rope) Rope {
+				return rope.AppendString("x")
+			})
+		}()
+	}
+	wg.Wait()
+
+	expectInt(100, buffer.Load().Length(), t)
+}
+
+func TestBufferSubscribe(t *testing.T) {
+	buffer := NewBuffer(NewString("hello"))
+	updates := buffer.Subscribe()
+
+	buffer.Store(NewString("world"))
+
+	select {
+	case rope := <-updates:
+		expectString("world", rope.String(), t)
+	default:
+		t.Fatalf("expected a notification on the subscriber channel")
+	}
+}
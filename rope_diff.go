@@ -0,0 +1,105 @@
+package rope
+
+// An Edit describes a single change needed to turn one rope into another:
+// deleting Delete bytes at Offset and inserting Insert in their place.
+type Edit struct {
+	Offset, Delete int
+	Insert         []byte
+}
+
+// Diff returns the edits needed to turn rope into other.
+// Because ropes share structure persistently, subtrees that are identical
+// by pointer are skipped without ever being compared byte-by-byte. The two
+// trees are only walked child by child where their left children have the
+// same length, or where one rope is a direct child of the other; anywhere
+// else the two subtrees are compared as whole strings, so an edit that
+// shifts a split point (such as an insert in the middle of a rope) costs
+// time proportional to the size of the subtrees holding it.
+func (rope Rope) Diff(other Rope) []Edit {
+	var edits []Edit
+	diffNode(rope, other, 0, &edits)
+	return edits
+}
+
+func diffNode(a, b Rope, offset int, edits *[]Edit) {
+	if a.length == 0 && b.length == 0 {
+		return
+	}
+
+	if a == b {
+		return
+	}
+
+	// A whole subtree can reappear as a child of the other tree at a
+	// different depth or position — an Insert at either edge of a rope
+	// does exactly this when Append wraps the untouched rope as a single
+	// child of the new root, and diffing in the other direction turns the
+	// same shape into a delete. Catching that here means edits at the front or back of a
+	// large shared document are found in O(1) instead of falling through
+	// to the full string compare below.
+	if !b.isLeaf() {
+		if a == *b.left {
+			*edits = append(*edits, Edit{Offset: offset + a.length, Insert: []byte(b.right.String())})
+			return
+		}
+		if a == *b.right {
+			*edits = append(*edits, Edit{Offset: offset, Insert: []byte(b.left.String())})
+			return
+		}
+	}
+	if !a.isLeaf() {
+		if b == *a.left {
+			*edits = append(*edits, Edit{Offset: offset + b.length, Delete: a.right.length})
+			return
+		}
+		if b == *a.right {
+			*edits = append(*edits, Edit{Offset: offset, Delete: a.left.length})
+			return
+		}
+	}
+
+	if !a.isLeaf() && !b.isLeaf() && a.left.length == b.left.length {
+		diffNode(*a.left, *b.left, offset, edits)
+		diffNode(*a.right, *b.right, offset+a.left.length, edits)
+		return
+	}
+
+	sa, sb := a.String(), b.String()
+	if sa == sb {
+		return
+	}
+
+	prefix := commonPrefixLen(sa, sb)
+	suffix := commonSuffixLen(sa[prefix:], sb[prefix:])
+	*edits = append(*edits, Edit{
+		Offset: offset + prefix,
+		Delete: len(sa) - prefix - suffix,
+		Insert: []byte(sb[prefix : len(sb)-suffix]),
+	})
+}
+
+func commonPrefixLen(a, b string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	i := 0
+	for i < n && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func commonSuffixLen(a, b string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	i := 0
+	for i < n && a[len(a)-1-i] == b[len(b)-1-i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,58 @@
+package rope
+
+import (
+	"strings"
+	"testing"
+)
+
+func applyEdits(s string, edits []Edit) string {
+	rope := NewString(s)
+	for _, edit := range edits {
+		rope = rope.Delete(edit.Offset, edit.Delete)
+		rope = rope.InsertString(edit.Offset, string(edit.Insert))
+	}
+	return rope.String()
+}
+
+func TestDiffIdentical(t *testing.T) {
+	rope := NewString("how now brown cow")
+	if edits := rope.Diff(rope); edits != nil {
+		t.Fatalf("expected no edits, got %v", edits)
+	}
+}
+
+func TestDiffReplace(t *testing.T) {
+	a := NewString("how now brown cow")
+	b := a.Delete(8, 6).InsertString(8, "red")
+
+	edits := a.Diff(b)
+	if len(edits) == 0 {
+		t.Fatalf("expected at least one edit")
+	}
+
+	expectString(b.String(), applyEdits(a.String(), edits), t)
+}
+
+func TestDiffSharedSubtreeIsSkipped(t *testing.T) {
+	shared := NewString(strings.Repeat("x", maxLeafSize)).AppendString(strings.Repeat("y", maxLeafSize))
+
+	a := shared.AppendString(" tail-a")
+	b := shared.AppendString(" tail-b")
+
+	edits := a.Diff(b)
+	expectInt(1, len(edits), t)
+	expectInt(shared.Length()+len(" tail-"), edits[0].Offset, t)
+}
+
+func TestDiffFrontInsertSharesSubtree(t *testing.T) {
+	shared := NewString(strings.Repeat("x", maxLeafSize)).AppendString(strings.Repeat("y", maxLeafSize))
+	inserted := shared.Insert(0, NewString("HEAD-"))
+
+	edits := shared.Diff(inserted)
+	expectInt(1, len(edits), t)
+	expectInt(0, edits[0].Offset, t)
+	expectInt(0, edits[0].Delete, t)
+	expectString("HEAD-", string(edits[0].Insert), t)
+
+	expectString(inserted.String(), applyEdits(shared.String(), edits), t)
+}
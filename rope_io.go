@@ -0,0 +1,90 @@
+package rope
+
+import "io"
+
+// WriteTo implements the standard io.WriterTo interface:
+// it writes the contents of the rope to w, walking leaves and writing each one directly
+// rather than first collecting the whole rope into a single string.
+func (rope Rope) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	var err error
+
+	rope.walk(func(node Rope) {
+		if err != nil {
+			return
+		}
+
+		var n int
+		n, err = io.WriteString(w, node.content)
+		total += int64(n)
+	})
+
+	return total, err
+}
+
+// ReadFrom implements the standard io.ReaderFrom interface:
+// it reads from r until EOF (or error), appending what it reads to the rope in
+// maxLeafSize-sized chunks and periodically rebalancing so large reads don't
+// leave the rope badly unbalanced.
+func (rope *Rope) ReadFrom(r io.Reader) (int64, error) {
+	var total int64
+	var reads int
+
+	buf := make([]byte, maxLeafSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			*rope = rope.AppendString(string(buf[:n]))
+			total += int64(n)
+
+			reads++
+			if reads%balanceFactor == 0 {
+				*rope = rope.Rebalance()
+			}
+		}
+
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// A LeafIterator walks the leaves of a rope in order, so callers can process a large rope
+// one chunk at a time instead of allocating it in a single string.
+type LeafIterator struct {
+	leaves []Rope
+	pos    int
+}
+
+// Return a new LeafIterator over the leaves of this rope.
+func (rope Rope) Chunks() *LeafIterator {
+	var leaves []Rope
+	rope.walk(func(node Rope) {
+		leaves = append(leaves, node)
+	})
+
+	return &LeafIterator{leaves: leaves, pos: -1}
+}
+
+// Next returns the next leaf's content, or false if the iterator is already at the last leaf.
+func (iterator *LeafIterator) Next() ([]byte, bool) {
+	if iterator.pos+1 >= len(iterator.leaves) {
+		return nil, false
+	}
+
+	iterator.pos++
+	return []byte(iterator.leaves[iterator.pos].content), true
+}
+
+// Prev returns the previous leaf's content, or false if the iterator is already at the first leaf.
+func (iterator *LeafIterator) Prev() ([]byte, bool) {
+	if iterator.pos <= 0 {
+		return nil, false
+	}
+
+	iterator.pos--
+	return []byte(iterator.leaves[iterator.pos].content), true
+}
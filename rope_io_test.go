@@ -0,0 +1,65 @@
+package rope
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	rope := NewString("hello, ").AppendString("world")
+
+	var buf bytes.Buffer
+	n, err := rope.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectInt(int(n), rope.Length(), t)
+	expectString("hello, world", buf.String(), t)
+}
+
+func TestReadFrom(t *testing.T) {
+	text := strings.Repeat("hello, world ", 1024)
+
+	rope := New()
+	n, err := rope.ReadFrom(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectInt(len(text), int(n), t)
+	expectString(text, rope.String(), t)
+}
+
+func TestChunks(t *testing.T) {
+	foo := NewString("foo")
+	bar := NewString("bar")
+	baz := NewString("baz")
+	right := Rope{depth: 1, length: bar.length + baz.length, left: &bar, right: &baz}
+	rope := Rope{depth: 2, length: foo.length + right.length, left: &foo, right: &right}
+
+	iterator := rope.Chunks()
+	var chunks []string
+	for {
+		chunk, ok := iterator.Next()
+		if !ok {
+			break
+		}
+		chunks = append(chunks, string(chunk))
+	}
+
+	expectString("foobarbaz", strings.Join(chunks, ""), t)
+
+	for i := len(chunks) - 2; i >= 0; i-- {
+		chunk, ok := iterator.Prev()
+		if !ok {
+			t.Fatalf("expected a previous chunk")
+		}
+		expectString(chunks[i], string(chunk), t)
+	}
+
+	if _, ok := iterator.Prev(); ok {
+		t.Fatalf("expected no chunk before the first")
+	}
+}
@@ -0,0 +1,104 @@
+package rope
+
+import "io"
+
+// Return the number of lines in the rope.
+// A rope with n newlines has n+1 lines, the same convention most editors use:
+// text that doesn't end in a newline still has a final, unterminated line.
+func (rope Rope) LineCount() int {
+	return rope.newlines + 1
+}
+
+// Return the byte offset at which line number line (0-indexed) begins.
+// Lookups descend the tree using the cached per-node newline counts, so this is O(log n).
+func (rope Rope) OffsetForLine(line int) int {
+	if line <= 0 {
+		return 0
+	}
+	return rope.offsetForLine(line)
+}
+
+func (rope Rope) offsetForLine(line int) int {
+	switch {
+	case rope.isLeaf():
+		n := 0
+		for i := 0; i < len(rope.content); i++ {
+			if rope.content[i] == '\n' {
+				n++
+				if n == line {
+					return i + 1
+				}
+			}
+		}
+		return rope.length
+	case line <= rope.left.newlines:
+		return rope.left.offsetForLine(line)
+	default:
+		return rope.left.length + rope.right.offsetForLine(line-rope.left.newlines)
+	}
+}
+
+// Return the 0-indexed line and column for byte offset off.
+func (rope Rope) LineColForOffset(off int) (line, col int) {
+	line = rope.lineForOffset(off)
+	col = off - rope.OffsetForLine(line)
+	return line, col
+}
+
+func (rope Rope) lineForOffset(off int) int {
+	switch {
+	case rope.isLeaf():
+		n := 0
+		for i := 0; i < off && i < len(rope.content); i++ {
+			if rope.content[i] == '\n' {
+				n++
+			}
+		}
+		return n
+	case off < rope.left.length:
+		return rope.left.lineForOffset(off)
+	default:
+		return rope.left.newlines + rope.right.lineForOffset(off-rope.left.length)
+	}
+}
+
+// Return the contents of line number i (0-indexed), excluding its trailing newline.
+func (rope Rope) Line(i int) []byte {
+	start := rope.OffsetForLine(i)
+	end := rope.OffsetForLine(i + 1)
+
+	line := rope.Slice(start, end)
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		line = line[:n-1]
+	}
+
+	return line
+}
+
+// A LineReader yields the lines of a rope one at a time, fetching each one with Line
+// rather than building the whole thing into memory up front.
+type LineReader struct {
+	rope Rope
+	line int
+}
+
+// Return a new LineReader attached to the given rope.
+func NewLineReader(rope Rope) *LineReader {
+	return rope.LineReader()
+}
+
+// Return a new LineReader attached to this rope.
+func (rope Rope) LineReader() *LineReader {
+	return &LineReader{rope: rope}
+}
+
+// Next returns the next line in the rope, or io.EOF once every line has been returned.
+func (reader *LineReader) Next() ([]byte, error) {
+	if reader.line >= reader.rope.LineCount() {
+		return nil, io.EOF
+	}
+
+	line := reader.rope.Line(reader.line)
+	reader.line++
+	return line, nil
+}
@@ -0,0 +1,90 @@
+package rope
+
+import (
+	"io"
+	"testing"
+)
+
+func TestLineCount(t *testing.T) {
+	rope := NewString("one\ntwo\nthree")
+	expectInt(3, rope.LineCount(), t)
+
+	rope = rope.AppendString("\n")
+	expectInt(4, rope.LineCount(), t)
+}
+
+func TestOffsetForLine(t *testing.T) {
+	rope := NewString("one\ntwo\nthree")
+	expectInt(0, rope.OffsetForLine(0), t)
+	expectInt(4, rope.OffsetForLine(1), t)
+	expectInt(8, rope.OffsetForLine(2), t)
+	expectInt(rope.Length(), rope.OffsetForLine(3), t)
+}
+
+func TestLineColForOffset(t *testing.T) {
+	rope := NewString("one\ntwo\nthree")
+
+	line, col := rope.LineColForOffset(5)
+	expectInt(1, line, t)
+	expectInt(1, col, t)
+
+	line, col = rope.LineColForOffset(0)
+	expectInt(0, line, t)
+	expectInt(0, col, t)
+}
+
+func TestLine(t *testing.T) {
+	rope := NewString("one\ntwo\nthree")
+	expectString("one", string(rope.Line(0)), t)
+	expectString("two", string(rope.Line(1)), t)
+	expectString("three", string(rope.Line(2)), t)
+
+	rope = NewString("one\ntwo\n")
+	expectString("one", string(rope.Line(0)), t)
+	expectString("two", string(rope.Line(1)), t)
+	expectString("", string(rope.Line(2)), t)
+}
+
+func TestOffsetForLineAcrossLeaves(t *testing.T) {
+	left := NewString("one\ntw")
+	right := NewString("o\nthree")
+	rope := Rope{
+		depth:    1,
+		length:   left.length + right.length,
+		newlines: left.newlines + right.newlines,
+		left:     &left,
+		right:    &right,
+	}
+
+	expectInt(0, rope.OffsetForLine(0), t)
+	expectInt(4, rope.OffsetForLine(1), t)
+	expectInt(8, rope.OffsetForLine(2), t)
+	expectInt(rope.Length(), rope.OffsetForLine(3), t)
+
+	line, col := rope.LineColForOffset(5)
+	expectInt(1, line, t)
+	expectInt(1, col, t)
+
+	expectString("two", string(rope.Line(1)), t)
+}
+
+func TestLineReader(t *testing.T) {
+	rope := NewString("one\ntwo\nthree")
+	reader := rope.LineReader()
+
+	var lines []string
+	for {
+		line, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines = append(lines, string(line))
+	}
+
+	if len(lines) != 3 || lines[0] != "one" || lines[1] != "two" || lines[2] != "three" {
+		t.Fatalf("unexpected lines: %v", lines)
+	}
+}
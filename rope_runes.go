@@ -0,0 +1,106 @@
+package rope
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// Return the length of the rope in runes.
+// Because each leaf caches its own rune count, this is an O(1) lookup rather than a full scan.
+func (rope Rope) RuneLength() int {
+	return rope.runes
+}
+
+// Return the rune at rune-index i.
+func (rope Rope) RuneAt(i int) rune {
+	leaf, at := rope.leafForRuneOffset(i)
+	for _, r := range leaf.content {
+		if at == 0 {
+			return r
+		}
+		at--
+	}
+	return utf8.RuneError
+}
+
+// Return a new rope with the rune r inserted at rune-index at.
+func (rope Rope) InsertRune(at int, r rune) Rope {
+	return rope.Insert(rope.byteOffsetForRune(at), NewString(string(r)))
+}
+
+// Return a new rope with count runes at rune-index offset deleted.
+func (rope Rope) DeleteRunes(offset, count int) Rope {
+	start := rope.byteOffsetForRune(offset)
+	end := rope.byteOffsetForRune(offset + count)
+	return rope.Delete(start, end-start)
+}
+
+// Returns two new ropes, split at rune-index at, the same way Split splits at a byte index.
+// Splitting on a rune boundary means neither returned rope can begin or end with a partial UTF-8 sequence.
+func (rope Rope) SplitRune(at int) (Rope, Rope) {
+	return rope.Split(rope.byteOffsetForRune(at))
+}
+
+// Descend the tree using the cached per-node rune counts to find the leaf holding rune-index at,
+// returning that leaf along with the rune's offset within it.
+func (rope Rope) leafForRuneOffset(at int) (Rope, int) {
+	switch {
+	case rope.isLeaf():
+		return rope, at
+	case at < rope.left.runes:
+		return rope.left.leafForRuneOffset(at)
+	default:
+		return rope.right.leafForRuneOffset(at - rope.left.runes)
+	}
+}
+
+// Translate a rune-index into the equivalent byte offset, descending via the cached rune counts
+// so that only the destination leaf is ever scanned rune-by-rune.
+func (rope Rope) byteOffsetForRune(at int) int {
+	switch {
+	case rope.isLeaf():
+		if at <= 0 {
+			return 0
+		}
+		n := 0
+		for i := range rope.content {
+			if n == at {
+				return i
+			}
+			n++
+		}
+		return rope.length
+	case at < rope.left.runes:
+		return rope.left.byteOffsetForRune(at)
+	default:
+		return rope.left.length + rope.right.byteOffsetForRune(at-rope.left.runes)
+	}
+}
+
+// A RuneReader provides an implementation of io.RuneReader for ropes.
+type RuneReader struct {
+	rope     Rope
+	position int
+}
+
+// Return a new RuneReader attached to the given rope.
+func NewRuneReader(rope Rope) *RuneReader {
+	return rope.RuneReader()
+}
+
+// Return a new RuneReader attached to this rope.
+func (rope Rope) RuneReader() *RuneReader {
+	return &RuneReader{rope: rope}
+}
+
+// ReadRune implements the standard io.RuneReader interface:
+// it reads and returns the next rune in the rope along with its size in bytes.
+func (reader *RuneReader) ReadRune() (r rune, size int, err error) {
+	if reader.position >= reader.rope.RuneLength() {
+		return 0, 0, io.EOF
+	}
+
+	r = reader.rope.RuneAt(reader.position)
+	reader.position++
+	return r, utf8.RuneLen(r), nil
+}
@@ -0,0 +1,96 @@
+package rope
+
+import (
+	"io"
+	"testing"
+)
+
+func TestRuneLength(t *testing.T) {
+	rope := NewString("héllo wörld")
+	expectInt(11, rope.RuneLength(), t)
+	expectInt(13, rope.Length(), t)
+}
+
+func TestRuneAt(t *testing.T) {
+	rope := NewString("héllo")
+	if rope.RuneAt(1) != 'é' {
+		t.Fatalf("expected 'é', got %q", rope.RuneAt(1))
+	}
+
+	rope1 := NewString("hé")
+	rope2 := NewString("llo")
+	rope3 := Rope{
+		depth:  1,
+		length: rope1.length + rope2.length,
+		runes:  rope1.runes + rope2.runes,
+		left:   &rope1,
+		right:  &rope2,
+	}
+
+	if rope3.RuneAt(1) != 'é' {
+		t.Fatalf("expected 'é', got %q", rope3.RuneAt(1))
+	}
+	if rope3.RuneAt(4) != 'o' {
+		t.Fatalf("expected 'o', got %q", rope3.RuneAt(4))
+	}
+}
+
+func TestSplitRune(t *testing.T) {
+	rope := NewString("héllo wörld")
+	left, right := rope.SplitRune(6)
+	expectString("héllo ", left.String(), t)
+	expectString("wörld", right.String(), t)
+}
+
+func TestInsertRune(t *testing.T) {
+	rope := NewString("hllo")
+	rope = rope.InsertRune(1, 'é')
+	expectString("héllo", rope.String(), t)
+}
+
+func TestDeleteRunes(t *testing.T) {
+	rope := NewString("héllo wörld")
+	rope = rope.DeleteRunes(5, 6)
+	expectString("héllo", rope.String(), t)
+}
+
+func TestSplitRuneAcrossLeaves(t *testing.T) {
+	left := NewString("héllo ")
+	right := NewString("wörld")
+	rope := Rope{
+		depth:  1,
+		length: left.length + right.length,
+		runes:  left.runes + right.runes,
+		left:   &left,
+		right:  &right,
+	}
+
+	a, b := rope.SplitRune(6)
+	expectString("héllo ", a.String(), t)
+	expectString("wörld", b.String(), t)
+
+	rope = rope.InsertRune(6, '!')
+	expectString("héllo !wörld", rope.String(), t)
+
+	rope = rope.DeleteRunes(6, 1)
+	expectString("héllo wörld", rope.String(), t)
+}
+
+func TestRuneReader(t *testing.T) {
+	rope := NewString("héllo")
+	reader := rope.RuneReader()
+
+	var runes []rune
+	for {
+		r, _, err := reader.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		runes = append(runes, r)
+	}
+
+	expectString("héllo", string(runes), t)
+}
@@ -0,0 +1,280 @@
+package rope
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// Return the index of the first instance of pattern in the rope, or -1 if pattern is not present.
+func (rope Rope) Index(pattern []byte) int {
+	if len(pattern) == 0 {
+		return 0
+	}
+	if rope.length < len(pattern) {
+		return -1
+	}
+
+	if i, ok := rope.FindAll(pattern).Next(); ok {
+		return i
+	}
+	return -1
+}
+
+// Return the index of the first instance of s in the rope, or -1 if s is not present.
+func (rope Rope) IndexString(s string) int {
+	return rope.Index([]byte(s))
+}
+
+// Return the index of the first instance of the rune r in the rope, or -1 if r is not present.
+func (rope Rope) IndexRune(r rune) int {
+	buf := make([]byte, utf8.RuneLen(r))
+	utf8.EncodeRune(buf, r)
+	return rope.Index(buf)
+}
+
+// Return the index of the last instance of pattern in the rope, or -1 if pattern is not present.
+func (rope Rope) LastIndex(pattern []byte) int {
+	if len(pattern) == 0 {
+		return rope.length
+	}
+
+	last := -1
+	iterator := rope.FindAll(pattern)
+	for {
+		i, ok := iterator.Next()
+		if !ok {
+			break
+		}
+		last = i
+	}
+	if last < 0 {
+		return -1
+	}
+
+	// FindAll skips instances that overlap an earlier match, so a later instance can only start
+	// before last+len(pattern); search that window for it.
+	end := last + 2*len(pattern) - 1
+	if end > rope.length {
+		end = rope.length
+	}
+	return last + bytes.LastIndex(rope.Slice(last, end), pattern)
+}
+
+// Return the number of non-overlapping instances of pattern in the rope.
+func (rope Rope) Count(pattern []byte) int {
+	if len(pattern) == 0 {
+		return rope.RuneLength() + 1
+	}
+
+	count := 0
+	iterator := rope.FindAll(pattern)
+	for {
+		if _, ok := iterator.Next(); !ok {
+			return count
+		}
+		count++
+	}
+}
+
+// Return a new rope with the first instance of old replaced by new.
+func (rope Rope) Replace(old, new []byte) Rope {
+	i := rope.Index(old)
+	if i < 0 {
+		return rope
+	}
+	return rope.Delete(i, len(old)).Insert(i, NewString(string(new)))
+}
+
+// Return a new rope with every non-overlapping instance of old replaced by new.
+func (rope Rope) ReplaceAll(old, new []byte) Rope {
+	if len(old) == 0 {
+		return rope
+	}
+
+	result := New()
+	remaining := rope
+	for {
+		i := remaining.Index(old)
+		if i < 0 {
+			return result.Append(remaining)
+		}
+
+		left, right := remaining.Split(i)
+		result = result.Append(left).AppendString(string(new))
+		_, remaining = right.Split(len(old))
+	}
+}
+
+// A searchTask is one piece of deferred work in a MatchIterator's traversal: either visit a node
+// looking for matches fully contained in it, or check the boundary between a node's two children
+// for a match that straddles the split.
+type searchTask struct {
+	boundary    bool
+	node        Rope
+	left, right Rope
+	offset      int
+}
+
+// A MatchIterator walks the tree for instances of a pattern one node at a time, expanding subtrees
+// only as Next is called, so a caller that stops after the first few matches never pays to examine
+// the rest of the rope.
+type MatchIterator struct {
+	pattern []byte
+	stack   []searchTask
+
+	next    int
+	pending []int
+	pendIdx int
+}
+
+// Return an iterator over the (possibly leaf-boundary-crossing) instances of pattern in the rope.
+// Each step of the walk either descends into a child whose cached length is at least len(pattern) —
+// anything shorter is skipped outright, since it can't hold a whole match — or compares the few
+// bytes on either side of a split to catch a match that crosses it, without ever pulling a child's
+// full content together just to search it.
+func (rope Rope) FindAll(pattern []byte) *MatchIterator {
+	iterator := &MatchIterator{pattern: pattern}
+	if len(pattern) > 0 {
+		iterator.stack = []searchTask{{node: rope, offset: 0}}
+	}
+	return iterator
+}
+
+// Next returns the offset of the next match, or false once there are no more.
+// It resumes the traversal from wherever the previous call left off, so it does no more work than
+// is needed to find the next match.
+func (iterator *MatchIterator) Next() (int, bool) {
+	for iterator.pendIdx >= len(iterator.pending) {
+		if !iterator.advance() {
+			return 0, false
+		}
+	}
+
+	offset := iterator.pending[iterator.pendIdx]
+	iterator.pendIdx++
+	return offset, true
+}
+
+// advance pops and processes tasks from the stack until one of them queues a match into pending.
+// It returns false once the stack is empty, true as soon as it has something new in pending.
+func (iterator *MatchIterator) advance() bool {
+	iterator.pending = iterator.pending[:0]
+	iterator.pendIdx = 0
+
+	for len(iterator.stack) > 0 {
+		task := iterator.stack[len(iterator.stack)-1]
+		iterator.stack = iterator.stack[:len(iterator.stack)-1]
+
+		if task.boundary {
+			iterator.scanBoundary(task)
+		} else {
+			iterator.scanNode(task)
+		}
+
+		if len(iterator.pending) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scanNode handles a single node: a leaf is searched directly, while an internal node too short to
+// hold a match is skipped, and any other internal node is expanded into its two children plus the
+// boundary between them.
+func (iterator *MatchIterator) scanNode(task searchTask) {
+	node := task.node
+	if node.length < len(iterator.pattern) {
+		return
+	}
+
+	if node.isLeaf() {
+		content := []byte(node.content)
+		pos := 0
+		for {
+			i := bytes.Index(content[pos:], iterator.pattern)
+			if i < 0 {
+				return
+			}
+			iterator.accept(task.offset + pos + i)
+			pos += i + 1
+		}
+	}
+
+	iterator.stack = append(iterator.stack, searchTask{node: *node.right, offset: task.offset + node.left.length})
+	iterator.stack = append(iterator.stack, searchTask{boundary: true, left: *node.left, right: *node.right, offset: task.offset})
+	iterator.stack = append(iterator.stack, searchTask{node: *node.left, offset: task.offset})
+}
+
+// scanBoundary catches matches that start in task.left but run on into task.right. It only ever
+// looks at the last len(pattern)-1 bytes of left and the first len(pattern)-1 bytes of right, so its
+// cost doesn't depend on the size of either child.
+func (iterator *MatchIterator) scanBoundary(task searchTask) {
+	edge := len(iterator.pattern) - 1
+	if edge == 0 {
+		return
+	}
+
+	tail := task.left.tailBytes(edge)
+	head := task.right.headBytes(edge)
+	buffer := append(append([]byte{}, tail...), head...)
+	base := task.offset + task.left.length - len(tail)
+
+	pos := 0
+	for {
+		i := bytes.Index(buffer[pos:], iterator.pattern)
+		if i < 0 {
+			return
+		}
+
+		abs := pos + i
+		if abs < len(tail) && abs+len(iterator.pattern) > len(tail) {
+			iterator.accept(base + abs)
+		}
+		pos = abs + 1
+	}
+}
+
+// accept queues offset as a match if it doesn't overlap the previously accepted one, enforcing the
+// same non-overlapping rule a single left-to-right scan would.
+func (iterator *MatchIterator) accept(offset int) {
+	if offset < iterator.next {
+		return
+	}
+	iterator.pending = append(iterator.pending, offset)
+	iterator.next = offset + len(iterator.pattern)
+}
+
+// headBytes returns the first min(n, rope.length) bytes of the rope's content.
+func (rope Rope) headBytes(n int) []byte {
+	switch {
+	case n <= 0:
+		return nil
+	case rope.isLeaf():
+		if n >= len(rope.content) {
+			return []byte(rope.content)
+		}
+		return []byte(rope.content[:n])
+	case rope.left.length >= n:
+		return rope.left.headBytes(n)
+	default:
+		return append(rope.left.headBytes(n), rope.right.headBytes(n-rope.left.length)...)
+	}
+}
+
+// tailBytes returns the last min(n, rope.length) bytes of the rope's content.
+func (rope Rope) tailBytes(n int) []byte {
+	switch {
+	case n <= 0:
+		return nil
+	case rope.isLeaf():
+		if n >= len(rope.content) {
+			return []byte(rope.content)
+		}
+		return []byte(rope.content[len(rope.content)-n:])
+	case rope.right.length >= n:
+		return rope.right.tailBytes(n)
+	default:
+		return append(rope.left.tailBytes(n-rope.right.length), rope.right.tailBytes(n)...)
+	}
+}
@@ -0,0 +1,85 @@
+package rope
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIndex(t *testing.T) {
+	rope := NewString("how now brown cow")
+	expectInt(4, rope.Index([]byte("now")), t)
+	expectInt(-1, rope.Index([]byte("cat")), t)
+	expectInt(0, rope.Index([]byte("")), t)
+}
+
+func TestIndexAcrossLeaves(t *testing.T) {
+	how := NewString("how ")
+	now := NewString("now ")
+	brown := NewString("brown cow")
+	right := Rope{depth: 1, length: now.length + brown.length, left: &now, right: &brown}
+	rope := Rope{depth: 2, length: how.length + right.length, left: &how, right: &right}
+
+	expectInt(4, rope.Index([]byte("now")), t)
+	expectInt(8, rope.Index([]byte("brown")), t)
+	expectInt(3, rope.Index([]byte(" now ")), t)
+}
+
+func TestIndexString(t *testing.T) {
+	rope := NewString("how now brown cow")
+	expectInt(4, rope.IndexString("now"), t)
+}
+
+func TestIndexRune(t *testing.T) {
+	rope := NewString("héllo")
+	expectInt(1, rope.IndexRune('é'), t)
+}
+
+func TestLastIndex(t *testing.T) {
+	rope := NewString("how now brown cow")
+	expectInt(15, rope.LastIndex([]byte("ow")), t)
+}
+
+func TestCount(t *testing.T) {
+	rope := NewString("how now brown cow")
+	expectInt(4, rope.Count([]byte("ow")), t)
+	expectInt(len("how now brown cow")+1, rope.Count([]byte("")), t)
+}
+
+func TestFindAllAcrossManyLeaves(t *testing.T) {
+	rope := NewString("ab")
+	for i := 0; i < 99; i++ {
+		next := NewString("ab")
+		rope = Rope{length: rope.length + next.length, depth: rope.depth + 1, left: refer(rope), right: &next}
+	}
+
+	iterator := rope.FindAll([]byte("bab"))
+	count := 0
+	for {
+		_, ok := iterator.Next()
+		if !ok {
+			break
+		}
+		count++
+	}
+	expectInt(50, count, t)
+}
+
+func TestIndexBetweenLargeLeaves(t *testing.T) {
+	haystack := NewString(strings.Repeat("a", maxLeafSize+1))
+	needle := NewString("needle")
+	rope := haystack.Append(needle).Append(haystack)
+
+	expectInt(haystack.length, rope.Index([]byte("needle")), t)
+}
+
+func TestReplace(t *testing.T) {
+	rope := NewString("how now brown cow")
+	rope = rope.Replace([]byte("brown"), []byte("red"))
+	expectString("how now red cow", rope.String(), t)
+}
+
+func TestReplaceAll(t *testing.T) {
+	rope := NewString("how now brown cow")
+	rope = rope.ReplaceAll([]byte("ow"), []byte("OW"))
+	expectString(strings.ReplaceAll("how now brown cow", "ow", "OW"), rope.String(), t)
+}
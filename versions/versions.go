@@ -0,0 +1,103 @@
+// The versions package adds a named-revision history on top of rope.Rope.
+// Because a Rope is already persistent, keeping old versions around costs
+// nothing beyond the entries slice itself.
+package versions
+
+import (
+	"fmt"
+
+	"github.com/deadpixi/rope"
+)
+
+// An Edit describes a single change between two versions.
+type Edit = rope.Edit
+
+type entry struct {
+	version uint64
+	name    string
+	root    rope.Rope
+}
+
+// A Log tracks a working Rope and an ordered history of named, numbered snapshots of it.
+type Log struct {
+	current rope.Rope
+	entries []entry
+	next    uint64
+}
+
+// Return a new Log with the given rope as its initial working version.
+func NewLog(root rope.Rope) *Log {
+	return &Log{current: root, next: 1}
+}
+
+// Return the log's current working rope.
+func (log *Log) Current() rope.Rope {
+	return log.current
+}
+
+// Replace the log's working rope, without saving a version for it.
+func (log *Log) SetCurrent(root rope.Rope) {
+	log.current = root
+}
+
+// Save the current working rope as a new named version, returning its version number.
+func (log *Log) Save(name string) uint64 {
+	version := log.next
+	log.next++
+	log.entries = append(log.entries, entry{version: version, name: name, root: log.current})
+	return version
+}
+
+// Load makes the given version the current working rope and returns it.
+func (log *Log) Load(version uint64) (rope.Rope, error) {
+	root, ok := log.lookup(version)
+	if !ok {
+		return rope.Rope{}, fmt.Errorf("versions: no such version %d", version)
+	}
+
+	log.current = root
+	return root, nil
+}
+
+// Return the most recently saved version, or the working rope if nothing has been saved yet.
+func (log *Log) Latest() rope.Rope {
+	if len(log.entries) == 0 {
+		return log.current
+	}
+	return log.entries[len(log.entries)-1].root
+}
+
+// Remove a saved version from the log. It has no effect if the version doesn't exist.
+func (log *Log) DeleteVersion(v uint64) {
+	for i, e := range log.entries {
+		if e.version == v {
+			log.entries = append(log.entries[:i], log.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// Diff returns the edits needed to turn version a into version b.
+// It returns nil if either version doesn't exist.
+func (log *Log) Diff(a, b uint64) []Edit {
+	rootA, ok := log.lookup(a)
+	if !ok {
+		return nil
+	}
+
+	rootB, ok := log.lookup(b)
+	if !ok {
+		return nil
+	}
+
+	return rootA.Diff(rootB)
+}
+
+func (log *Log) lookup(version uint64) (rope.Rope, bool) {
+	for _, e := range log.entries {
+		if e.version == version {
+			return e.root, true
+		}
+	}
+	return rope.Rope{}, false
+}
@@ -0,0 +1,75 @@
+package versions
+
+import (
+	"testing"
+
+	"github.com/deadpixi/rope"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	log := NewLog(rope.NewString("hello"))
+	v1 := log.Save("initial")
+
+	log.SetCurrent(log.Current().AppendString(", world"))
+	v2 := log.Save("greeting")
+
+	root, err := log.Load(v1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if root.String() != "hello" {
+		t.Fatalf("expected 'hello', got %q", root.String())
+	}
+
+	root, err = log.Load(v2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if root.String() != "hello, world" {
+		t.Fatalf("expected 'hello, world', got %q", root.String())
+	}
+}
+
+func TestLoadMissingVersion(t *testing.T) {
+	log := NewLog(rope.NewString("hello"))
+	if _, err := log.Load(99); err == nil {
+		t.Fatalf("expected an error for a missing version")
+	}
+}
+
+func TestLatest(t *testing.T) {
+	log := NewLog(rope.NewString("hello"))
+	if log.Latest().String() != "hello" {
+		t.Fatalf("expected 'hello' before any save")
+	}
+
+	log.SetCurrent(log.Current().AppendString("!"))
+	log.Save("v1")
+
+	if log.Latest().String() != "hello!" {
+		t.Fatalf("expected 'hello!', got %q", log.Latest().String())
+	}
+}
+
+func TestDeleteVersion(t *testing.T) {
+	log := NewLog(rope.NewString("hello"))
+	v1 := log.Save("v1")
+
+	log.DeleteVersion(v1)
+	if _, err := log.Load(v1); err == nil {
+		t.Fatalf("expected version to be gone")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	log := NewLog(rope.NewString("hello"))
+	v1 := log.Save("v1")
+
+	log.SetCurrent(log.Current().AppendString(", world"))
+	v2 := log.Save("v2")
+
+	edits := log.Diff(v1, v2)
+	if len(edits) == 0 {
+		t.Fatalf("expected at least one edit")
+	}
+}